@@ -0,0 +1,363 @@
+package actor
+
+// BroadcastOverflowPolicy controls what Publish does for a given subscriber
+// when that subscriber isn't keeping up.
+type BroadcastOverflowPolicy int
+
+const (
+	// BroadcastBlock delivers every published value to the subscriber,
+	// applying backpressure to Publish if the subscriber falls behind.
+	// Requires OptMaxSize: without it the subscriber's mailbox is unbounded
+	// and Publish never actually blocks, it just grows the queue.
+	BroadcastBlock BroadcastOverflowPolicy = iota
+	// BroadcastDropSlow discards a published value for this subscriber
+	// rather than waiting for it to catch up. Requires OptMaxSize: without
+	// it the subscriber's mailbox is unbounded and nothing is ever dropped.
+	BroadcastDropSlow
+	// BroadcastBuffered gives the subscriber a bounded buffer so it can
+	// absorb bursts before Publish starts applying backpressure. Requires
+	// OptMaxSize: without it the buffer degenerates to an unbuffered channel.
+	BroadcastBuffered
+)
+
+// OptBroadcastPolicy sets the BroadcastOverflowPolicy a Broadcaster subscriber
+// uses once it falls behind. Defaults to BroadcastBlock.
+func OptBroadcastPolicy(p BroadcastOverflowPolicy) Option {
+	return func(o *options) {
+		o.Mailbox.BroadcastPolicy = p
+	}
+}
+
+// Broadcaster fans a stream of published values out to a dynamic set of
+// subscribers. Unlike FanOut, which takes the full sender list up front and
+// blocks on the slowest consumer, subscribers can come and go at any time and
+// each one has its own overflow policy. A lagging BroadcastDropSlow subscriber
+// never stalls delivery to the others; a full BroadcastBlock or
+// BroadcastBuffered subscriber makes Publish wait, which holds back later
+// values for every subscriber.
+type Broadcaster[T any] interface {
+	Actor
+
+	// Subscribe registers a new subscriber and returns its receive side.
+	// opt configures the new subscriber's mailbox, notably OptBroadcastPolicy
+	// and OptMaxSize. OptMaxSize must be supplied for BroadcastBlock to
+	// actually apply backpressure, for BroadcastDropSlow to ever drop, and
+	// for BroadcastBuffered to have a real buffer: without it the underlying
+	// mailbox is unbounded, so BroadcastBlock never blocks, BroadcastDropSlow
+	// never drops, and BroadcastBuffered degenerates to an unbuffered
+	// channel.
+	Subscribe(opt ...Option) MailboxReceiver[T]
+
+	// Unsubscribe removes a subscriber previously returned by Subscribe.
+	// Publishing continues unaffected for remaining subscribers.
+	Unsubscribe(r MailboxReceiver[T])
+
+	// Publish delivers v to every current subscriber, per its overflow
+	// policy.
+	Publish(v T)
+}
+
+// NewBroadcaster returns new Broadcaster. opt sets the default Option applied
+// to subscribers that don't override it in their own Subscribe call.
+func NewBroadcaster[T any](opt ...Option) Broadcaster[T] {
+	var (
+		subscribeC   = make(chan broadcastSubscribeRequest[T])
+		unsubscribeC = make(chan MailboxReceiver[T])
+		publishC     = make(chan T)
+		w            = newBroadcasterWorker(subscribeC, unsubscribeC, publishC, opt)
+	)
+
+	return &broadcaster[T]{
+		Actor:        New(w),
+		subscribeC:   subscribeC,
+		unsubscribeC: unsubscribeC,
+		publishC:     publishC,
+	}
+}
+
+type broadcaster[T any] struct {
+	Actor
+	subscribeC   chan broadcastSubscribeRequest[T]
+	unsubscribeC chan MailboxReceiver[T]
+	publishC     chan T
+}
+
+func (b *broadcaster[T]) Subscribe(opt ...Option) MailboxReceiver[T] {
+	reply := make(chan MailboxReceiver[T], 1)
+	b.subscribeC <- broadcastSubscribeRequest[T]{opt: opt, reply: reply}
+	return <-reply
+}
+
+func (b *broadcaster[T]) Unsubscribe(r MailboxReceiver[T]) {
+	b.unsubscribeC <- r
+}
+
+func (b *broadcaster[T]) Publish(v T) {
+	b.publishC <- v
+}
+
+type broadcastSubscribeRequest[T any] struct {
+	opt   []Option
+	reply chan MailboxReceiver[T]
+}
+
+type broadcastSubscriber[T any] struct {
+	mailbox Mailbox[T]
+	policy  BroadcastOverflowPolicy
+}
+
+type broadcasterWorker[T any] struct {
+	subscribeC   chan broadcastSubscribeRequest[T]
+	unsubscribeC chan MailboxReceiver[T]
+	publishC     chan T
+	defaultOpt   []Option
+
+	subscribers []*broadcastSubscriber[T]
+}
+
+func newBroadcasterWorker[T any](
+	subscribeC chan broadcastSubscribeRequest[T],
+	unsubscribeC chan MailboxReceiver[T],
+	publishC chan T,
+	defaultOpt []Option,
+) *broadcasterWorker[T] {
+	return &broadcasterWorker[T]{
+		subscribeC:   subscribeC,
+		unsubscribeC: unsubscribeC,
+		publishC:     publishC,
+		defaultOpt:   defaultOpt,
+	}
+}
+
+func (w *broadcasterWorker[T]) DoWork(c Context) WorkerStatus {
+	select {
+	case req := <-w.subscribeC:
+		req.reply <- w.subscribe(req.opt)
+		return WorkerContinue
+
+	case r := <-w.unsubscribeC:
+		w.unsubscribe(r)
+		return WorkerContinue
+
+	case v := <-w.publishC:
+		w.publish(v)
+		return WorkerContinue
+
+	case <-c.Done():
+		return WorkerEnd
+	}
+}
+
+func (w *broadcasterWorker[T]) subscribe(opt []Option) MailboxReceiver[T] {
+	opts := newOptions(append(append([]Option{}, w.defaultOpt...), opt...))
+
+	var m Mailbox[T]
+	switch opts.Mailbox.BroadcastPolicy {
+	case BroadcastDropSlow:
+		m = NewBoundedMailbox[T](append(append([]Option{}, w.defaultOpt...), append(opt, OptOverflowPolicy(DropNewest))...)...)
+	case BroadcastBuffered:
+		bufOpt := append(opt, OptAsChan())
+		if maxSize := opts.Mailbox.MaxSize; maxSize > 0 {
+			// The channel-backed mailbox takes its buffer size from Capacity.
+			bufOpt = append(bufOpt, func(o *options) { o.Mailbox.Capacity = maxSize })
+		}
+		m = NewMailbox[T](append(append([]Option{}, w.defaultOpt...), bufOpt...)...)
+	default: // BroadcastBlock
+		if opts.Mailbox.MaxSize > 0 {
+			// Route through BoundedMailbox with the Block policy so a
+			// full mailbox actually makes SendC() block, rather than the
+			// unbounded queue-worker mailbox, which never blocks.
+			m = NewBoundedMailbox[T](append(append([]Option{}, w.defaultOpt...), append(opt, OptOverflowPolicy(Block))...)...)
+		} else {
+			m = NewMailbox[T](append(append([]Option{}, w.defaultOpt...), opt...)...)
+		}
+	}
+
+	m.Start()
+
+	w.subscribers = append(w.subscribers, &broadcastSubscriber[T]{
+		mailbox: m,
+		policy:  opts.Mailbox.BroadcastPolicy,
+	})
+
+	return m
+}
+
+func (w *broadcasterWorker[T]) unsubscribe(r MailboxReceiver[T]) {
+	for i, s := range w.subscribers {
+		if s.mailbox == r {
+			s.mailbox.Stop()
+			w.subscribers = append(w.subscribers[:i], w.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// publish delivers v to every subscriber, in parallel, so a subscriber that
+// blocks (BroadcastBlock, or a full BroadcastBuffered buffer) only delays its
+// own delivery rather than the others'.
+func (w *broadcasterWorker[T]) publish(v T) {
+	done := make(chan struct{}, len(w.subscribers))
+
+	for _, s := range w.subscribers {
+		s := s
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			if s.policy == BroadcastDropSlow {
+				_ = s.mailbox.(BoundedMailbox[T]).TrySend(v)
+				return
+			}
+
+			s.mailbox.SendC() <- v
+		}()
+	}
+
+	for range w.subscribers {
+		<-done
+	}
+}
+
+func (w *broadcasterWorker[T]) OnStop() {
+	for _, s := range w.subscribers {
+		s.mailbox.Stop()
+	}
+
+	close(w.subscribeC)
+	close(w.unsubscribeC)
+	close(w.publishC)
+}
+
+// TopicBroker is a pub/sub broker that fans published values out to
+// subscribers keyed by topic, lazily creating a Broadcaster per topic.
+type TopicBroker[K comparable, T any] interface {
+	Actor
+
+	// Subscribe registers a new subscriber for topic.
+	Subscribe(topic K, opt ...Option) MailboxReceiver[T]
+
+	// Unsubscribe removes a subscriber previously returned by Subscribe for topic.
+	Unsubscribe(topic K, r MailboxReceiver[T])
+
+	// Publish delivers v to every current subscriber of topic.
+	Publish(topic K, v T)
+}
+
+// NewTopicBroker returns new TopicBroker.
+func NewTopicBroker[K comparable, T any]() TopicBroker[K, T] {
+	var (
+		subscribeC   = make(chan topicSubscribeRequest[K, T])
+		unsubscribeC = make(chan topicUnsubscribeRequest[K, T])
+		publishC     = make(chan topicPublishRequest[K, T])
+		w            = newTopicBrokerWorker(subscribeC, unsubscribeC, publishC)
+	)
+
+	return &topicBroker[K, T]{
+		Actor:        New(w),
+		subscribeC:   subscribeC,
+		unsubscribeC: unsubscribeC,
+		publishC:     publishC,
+	}
+}
+
+type topicBroker[K comparable, T any] struct {
+	Actor
+	subscribeC   chan topicSubscribeRequest[K, T]
+	unsubscribeC chan topicUnsubscribeRequest[K, T]
+	publishC     chan topicPublishRequest[K, T]
+}
+
+func (b *topicBroker[K, T]) Subscribe(topic K, opt ...Option) MailboxReceiver[T] {
+	reply := make(chan MailboxReceiver[T], 1)
+	b.subscribeC <- topicSubscribeRequest[K, T]{topic: topic, opt: opt, reply: reply}
+	return <-reply
+}
+
+func (b *topicBroker[K, T]) Unsubscribe(topic K, r MailboxReceiver[T]) {
+	b.unsubscribeC <- topicUnsubscribeRequest[K, T]{topic: topic, receiver: r}
+}
+
+func (b *topicBroker[K, T]) Publish(topic K, v T) {
+	b.publishC <- topicPublishRequest[K, T]{topic: topic, value: v}
+}
+
+type topicSubscribeRequest[K comparable, T any] struct {
+	topic K
+	opt   []Option
+	reply chan MailboxReceiver[T]
+}
+
+type topicUnsubscribeRequest[K comparable, T any] struct {
+	topic    K
+	receiver MailboxReceiver[T]
+}
+
+type topicPublishRequest[K comparable, T any] struct {
+	topic K
+	value T
+}
+
+type topicBrokerWorker[K comparable, T any] struct {
+	subscribeC   chan topicSubscribeRequest[K, T]
+	unsubscribeC chan topicUnsubscribeRequest[K, T]
+	publishC     chan topicPublishRequest[K, T]
+
+	topics map[K]Broadcaster[T]
+}
+
+func newTopicBrokerWorker[K comparable, T any](
+	subscribeC chan topicSubscribeRequest[K, T],
+	unsubscribeC chan topicUnsubscribeRequest[K, T],
+	publishC chan topicPublishRequest[K, T],
+) *topicBrokerWorker[K, T] {
+	return &topicBrokerWorker[K, T]{
+		subscribeC:   subscribeC,
+		unsubscribeC: unsubscribeC,
+		publishC:     publishC,
+		topics:       make(map[K]Broadcaster[T]),
+	}
+}
+
+func (w *topicBrokerWorker[K, T]) DoWork(c Context) WorkerStatus {
+	select {
+	case req := <-w.subscribeC:
+		req.reply <- w.topic(req.topic).Subscribe(req.opt...)
+		return WorkerContinue
+
+	case req := <-w.unsubscribeC:
+		if b, ok := w.topics[req.topic]; ok {
+			b.Unsubscribe(req.receiver)
+		}
+		return WorkerContinue
+
+	case req := <-w.publishC:
+		if b, ok := w.topics[req.topic]; ok {
+			b.Publish(req.value)
+		}
+		return WorkerContinue
+
+	case <-c.Done():
+		return WorkerEnd
+	}
+}
+
+func (w *topicBrokerWorker[K, T]) topic(topic K) Broadcaster[T] {
+	b, ok := w.topics[topic]
+	if !ok {
+		b = NewBroadcaster[T]()
+		b.Start()
+		w.topics[topic] = b
+	}
+
+	return b
+}
+
+func (w *topicBrokerWorker[K, T]) OnStop() {
+	for _, b := range w.topics {
+		b.Stop()
+	}
+
+	close(w.subscribeC)
+	close(w.unsubscribeC)
+	close(w.publishC)
+}
@@ -0,0 +1,149 @@
+package actor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBroadcaster_PublishDeliversToAllSubscribers(t *testing.T) {
+	b := NewBroadcaster[int]()
+	b.Start()
+	defer b.Stop()
+
+	r1 := b.Subscribe()
+	r2 := b.Subscribe()
+
+	b.Publish(1)
+
+	for _, r := range []MailboxReceiver[int]{r1, r2} {
+		select {
+		case v := <-r.ReceiveC():
+			if v != 1 {
+				t.Fatalf("got %d, want %d", v, 1)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for delivery")
+		}
+	}
+}
+
+func TestBroadcaster_UnsubscribeStopsDelivery(t *testing.T) {
+	b := NewBroadcaster[int]()
+	b.Start()
+	defer b.Stop()
+
+	r := b.Subscribe()
+	b.Unsubscribe(r)
+
+	done := make(chan struct{})
+	go func() {
+		b.Publish(1)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish should not block on an unsubscribed receiver")
+	}
+
+	select {
+	case v, ok := <-r.ReceiveC():
+		if ok {
+			t.Fatalf("unsubscribed receiver got %d, want closed channel", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for unsubscribed receiver's channel to close")
+	}
+}
+
+func TestBroadcaster_DropSlowDoesNotBlockOnLaggingSubscriber(t *testing.T) {
+	b := NewBroadcaster[int]()
+	b.Start()
+	defer b.Stop()
+
+	r := b.Subscribe(OptBroadcastPolicy(BroadcastDropSlow), OptMaxSize(1))
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			b.Publish(i)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish should not block on a lagging BroadcastDropSlow subscriber")
+	}
+
+	select {
+	case <-r.ReceiveC():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for at least one delivered value")
+	}
+}
+
+func TestBroadcaster_BlockAppliesBackpressureWithMaxSize(t *testing.T) {
+	b := NewBroadcaster[int]()
+	b.Start()
+	defer b.Stop()
+
+	r := b.Subscribe(OptBroadcastPolicy(BroadcastBlock), OptMaxSize(1))
+
+	b.Publish(1) // fills the subscriber's bounded mailbox.
+
+	secondPublished := make(chan struct{})
+	go func() {
+		b.Publish(2)
+		close(secondPublished)
+	}()
+
+	select {
+	case <-secondPublished:
+		t.Fatal("Publish should block while the BroadcastBlock subscriber's mailbox is full")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	select {
+	case v := <-r.ReceiveC():
+		if v != 1 {
+			t.Fatalf("got %d, want %d", v, 1)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for first delivery")
+	}
+
+	select {
+	case <-secondPublished:
+	case <-time.After(time.Second):
+		t.Fatal("Publish should unblock once the subscriber drains the mailbox")
+	}
+}
+
+func TestTopicBroker_PublishOnlyReachesMatchingTopic(t *testing.T) {
+	b := NewTopicBroker[string, int]()
+	b.Start()
+	defer b.Stop()
+
+	rA := b.Subscribe("a")
+	rB := b.Subscribe("b")
+
+	b.Publish("a", 1)
+
+	select {
+	case v := <-rA.ReceiveC():
+		if v != 1 {
+			t.Fatalf("got %d, want %d", v, 1)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery to topic a")
+	}
+
+	select {
+	case v := <-rB.ReceiveC():
+		t.Fatalf("subscriber of topic b should not receive topic a's publish, got %d", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
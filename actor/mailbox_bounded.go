@@ -0,0 +1,249 @@
+package actor
+
+import "errors"
+
+// ErrMailboxFull is returned by BoundedMailbox.TrySend when the mailbox is at
+// MaxSize and the configured OverflowPolicy refuses the new value.
+var ErrMailboxFull = errors.New("actor: mailbox is full")
+
+// OverflowPolicy controls what a BoundedMailbox does when a send arrives
+// while the queue is already at MaxSize.
+type OverflowPolicy int
+
+const (
+	// DropNewest discards the incoming value, keeping the queue unchanged.
+	DropNewest OverflowPolicy = iota
+	// DropOldest evicts the value at the front of the queue to make room for
+	// the incoming one.
+	DropOldest
+	// Block makes SendC() block until space is available, same as an
+	// unbounded mailbox would behave if it had a cap. TrySend never blocks,
+	// so under this policy it behaves like Fail.
+	Block
+	// Fail refuses the incoming value. SendC() still blocks (a plain channel
+	// send has no way to report failure); TrySend returns ErrMailboxFull.
+	Fail
+)
+
+// OptMaxSize bounds a mailbox's queue to at most n messages. Combined with
+// OptOverflowPolicy it selects BoundedMailbox's eviction behavior once full.
+func OptMaxSize(n int) Option {
+	return func(o *options) {
+		o.Mailbox.MaxSize = n
+	}
+}
+
+// OptOverflowPolicy sets the BoundedMailbox eviction strategy used once
+// MaxSize is reached. Defaults to DropNewest.
+func OptOverflowPolicy(p OverflowPolicy) Option {
+	return func(o *options) {
+		o.Mailbox.OverflowPolicy = p
+	}
+}
+
+// OptOnDrop registers a callback invoked whenever a BoundedMailbox drops a
+// value, with the dropped value. Useful for exposing drop counts as metrics.
+func OptOnDrop[T any](fn func(T)) Option {
+	return func(o *options) {
+		o.Mailbox.OnDrop = fn
+	}
+}
+
+// BoundedMailbox is a Mailbox variant with a hard queue size limit and a
+// configurable OverflowPolicy, sitting between an unbounded queue-worker
+// mailbox and a fixed-capacity channel whose only backpressure is blocking.
+type BoundedMailbox[T any] interface {
+	Mailbox[T]
+
+	// TrySend attempts to enqueue v without blocking. It returns
+	// ErrMailboxFull if the mailbox is full and the overflow policy refuses
+	// the value.
+	TrySend(v T) error
+}
+
+// NewBoundedMailbox returns new BoundedMailbox. OptMaxSize must be set to a
+// positive value; without it the mailbox behaves as unbounded.
+func NewBoundedMailbox[T any](opt ...Option) BoundedMailbox[T] {
+	var (
+		opts  = newOptions(opt)
+		mOpts = opts.Mailbox
+
+		onDrop func(T)
+	)
+
+	if fn, ok := mOpts.OnDrop.(func(T)); ok {
+		onDrop = fn
+	}
+
+	var (
+		sendC    = make(chan T)
+		receiveC = make(chan T)
+		trySendC = make(chan boundedTrySend[T])
+		queue    = newQueue[T](mOpts.Capacity, mOpts.MinCapacity)
+		w        = newBoundedMailboxWorker(sendC, receiveC, trySendC, queue, mOpts.MaxSize, mOpts.OverflowPolicy, onDrop)
+	)
+
+	return &boundedMailbox[T]{
+		Actor:    New(w),
+		sendC:    sendC,
+		receiveC: receiveC,
+		trySendC: trySendC,
+	}
+}
+
+type boundedTrySend[T any] struct {
+	value T
+	reply chan error
+}
+
+type boundedMailbox[T any] struct {
+	Actor
+	sendC    chan<- T
+	receiveC <-chan T
+	trySendC chan<- boundedTrySend[T]
+}
+
+func (m *boundedMailbox[T]) SendC() chan<- T {
+	return m.sendC
+}
+
+func (m *boundedMailbox[T]) ReceiveC() <-chan T {
+	return m.receiveC
+}
+
+func (m *boundedMailbox[T]) TrySend(v T) error {
+	reply := make(chan error, 1)
+	m.trySendC <- boundedTrySend[T]{value: v, reply: reply}
+	return <-reply
+}
+
+type boundedMailboxWorker[T any] struct {
+	sendC    chan T
+	receiveC chan T
+	trySendC chan boundedTrySend[T]
+	queue    *queue[T]
+
+	maxSize int
+	policy  OverflowPolicy
+	onDrop  func(T)
+}
+
+func newBoundedMailboxWorker[T any](
+	sendC,
+	receiveC chan T,
+	trySendC chan boundedTrySend[T],
+	queue *queue[T],
+	maxSize int,
+	policy OverflowPolicy,
+	onDrop func(T),
+) *boundedMailboxWorker[T] {
+	return &boundedMailboxWorker[T]{
+		sendC:    sendC,
+		receiveC: receiveC,
+		trySendC: trySendC,
+		queue:    queue,
+		maxSize:  maxSize,
+		policy:   policy,
+		onDrop:   onDrop,
+	}
+}
+
+func (w *boundedMailboxWorker[T]) DoWork(c Context) WorkerStatus {
+	if w.queue.IsEmpty() {
+		select {
+		case value := <-w.acceptC():
+			w.push(value)
+			return WorkerContinue
+
+		case req := <-w.trySendC:
+			req.reply <- w.tryPush(req.value)
+			return WorkerContinue
+
+		case <-c.Done():
+			return WorkerEnd
+		}
+	}
+
+	select {
+	case w.receiveC <- w.queue.Front():
+		w.queue.PopFront()
+		return WorkerContinue
+
+	case value := <-w.acceptC():
+		w.push(value)
+		return WorkerContinue
+
+	case req := <-w.trySendC:
+		req.reply <- w.tryPush(req.value)
+		return WorkerContinue
+
+	case <-c.Done():
+		return WorkerEnd
+	}
+}
+
+// acceptC returns the sendC channel to select on: under the Block and Fail
+// policies it stops reading from sendC once the mailbox is full, so the
+// sender's channel send naturally blocks until space frees up.
+func (w *boundedMailboxWorker[T]) acceptC() chan T {
+	if (w.policy == Block || w.policy == Fail) && w.full() {
+		return nil
+	}
+	return w.sendC
+}
+
+func (w *boundedMailboxWorker[T]) full() bool {
+	return w.maxSize > 0 && w.queue.Len() >= w.maxSize
+}
+
+// push enqueues value from the blocking SendC() path, applying the overflow
+// policy when the mailbox is full.
+func (w *boundedMailboxWorker[T]) push(value T) {
+	if !w.full() {
+		w.queue.PushBack(value)
+		return
+	}
+
+	switch w.policy {
+	case DropOldest:
+		evicted := w.queue.Front()
+		w.queue.PopFront()
+		w.drop(evicted)
+		w.queue.PushBack(value)
+
+	default: // DropNewest (Block and Fail never get here: acceptC stops reading sendC once full)
+		w.drop(value)
+	}
+}
+
+// tryPush enqueues value from the non-blocking TrySend() path.
+func (w *boundedMailboxWorker[T]) tryPush(value T) error {
+	if !w.full() {
+		w.queue.PushBack(value)
+		return nil
+	}
+
+	switch w.policy {
+	case DropOldest:
+		evicted := w.queue.Front()
+		w.queue.PopFront()
+		w.drop(evicted)
+		w.queue.PushBack(value)
+		return nil
+
+	default: // DropNewest, Block, Fail
+		w.drop(value)
+		return ErrMailboxFull
+	}
+}
+
+func (w *boundedMailboxWorker[T]) drop(value T) {
+	if w.onDrop != nil {
+		w.onDrop(value)
+	}
+}
+
+func (w *boundedMailboxWorker[T]) OnStop() {
+	close(w.sendC)
+	close(w.receiveC)
+}
@@ -0,0 +1,72 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBoundedMailbox_DropNewestRefusesOnFull(t *testing.T) {
+	m := NewBoundedMailbox[int](OptMaxSize(1), OptOverflowPolicy(DropNewest))
+	m.Start()
+	defer m.Stop()
+
+	if err := m.TrySend(1); err != nil {
+		t.Fatalf("TrySend(1): %v", err)
+	}
+
+	if err := m.TrySend(2); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("TrySend(2) = %v, want %v", err, ErrMailboxFull)
+	}
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != 1 {
+			t.Fatalf("got %d, want the original value %d to survive", v, 1)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+func TestBoundedMailbox_DropOldestEvictsFront(t *testing.T) {
+	m := NewBoundedMailbox[int](OptMaxSize(1), OptOverflowPolicy(DropOldest))
+	m.Start()
+	defer m.Stop()
+
+	if err := m.TrySend(1); err != nil {
+		t.Fatalf("TrySend(1): %v", err)
+	}
+
+	if err := m.TrySend(2); err != nil {
+		t.Fatalf("TrySend(2): %v", err)
+	}
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != 2 {
+			t.Fatalf("got %d, want the newer value %d to have evicted the older one", v, 2)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+func TestBoundedMailbox_OnDropInvokedForRefusedValue(t *testing.T) {
+	var dropped []int
+
+	m := NewBoundedMailbox[int](
+		OptMaxSize(1),
+		OptOverflowPolicy(DropNewest),
+		OptOnDrop[int](func(v int) { dropped = append(dropped, v) }),
+	)
+	m.Start()
+	defer m.Stop()
+
+	_ = m.TrySend(1)
+	_ = m.TrySend(2)
+
+	if len(dropped) != 1 || dropped[0] != 2 {
+		t.Fatalf("dropped = %v, want [2]", dropped)
+	}
+}
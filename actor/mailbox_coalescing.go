@@ -0,0 +1,164 @@
+package actor
+
+// OptCoalesceReducer sets the function used by a CoalescingMailbox to merge a
+// newly sent value into the one already queued under the same key, instead of
+// replacing it outright. Has no effect on other Mailbox variants.
+func OptCoalesceReducer[T any](reducer func(old, new T) T) Option {
+	return func(o *options) {
+		o.Mailbox.CoalesceReducer = reducer
+	}
+}
+
+// NewCoalescingMailbox returns new Mailbox that only ever holds the freshest
+// value per key: sending a value whose key already has an entry queued
+// replaces that entry in place, preserving its original FIFO position,
+// instead of appending a duplicate. Pass OptCoalesceReducer to merge the old
+// and new values rather than replacing.
+func NewCoalescingMailbox[T any, K comparable](keyFn func(T) K, opt ...Option) Mailbox[T] {
+	var (
+		opts  = newOptions(opt)
+		mOpts = opts.Mailbox
+
+		reducer func(old, new T) T
+	)
+
+	if r, ok := mOpts.CoalesceReducer.(func(old, new T) T); ok {
+		reducer = r
+	}
+
+	var (
+		sendC    = make(chan T)
+		receiveC = make(chan T)
+		cq       = newCoalesceQueue[T, K](keyFn, reducer)
+		w        = newCoalescingMailboxWorker(sendC, receiveC, cq)
+	)
+
+	return &mailbox[T]{
+		Actor:    New(w),
+		sendC:    sendC,
+		receiveC: receiveC,
+	}
+}
+
+type coalescingMailboxWorker[T any, K comparable] struct {
+	receiveC chan T
+	sendC    chan T
+	queue    *coalesceQueue[T, K]
+}
+
+func newCoalescingMailboxWorker[T any, K comparable](
+	sendC,
+	receiveC chan T,
+	queue *coalesceQueue[T, K],
+) *coalescingMailboxWorker[T, K] {
+	return &coalescingMailboxWorker[T, K]{
+		sendC:    sendC,
+		receiveC: receiveC,
+		queue:    queue,
+	}
+}
+
+func (w *coalescingMailboxWorker[T, K]) DoWork(c Context) WorkerStatus {
+	if w.queue.IsEmpty() {
+		select {
+		case value := <-w.sendC:
+			w.queue.PushOrReplace(value)
+			return WorkerContinue
+
+		case <-c.Done():
+			return WorkerEnd
+		}
+	}
+
+	select {
+	case w.receiveC <- w.queue.Front():
+		w.queue.PopFront()
+		return WorkerContinue
+
+	case value := <-w.sendC:
+		w.queue.PushOrReplace(value)
+		return WorkerContinue
+
+	case <-c.Done():
+		return WorkerEnd
+	}
+}
+
+func (w *coalescingMailboxWorker[T, K]) OnStop() {
+	close(w.sendC)
+	close(w.receiveC)
+}
+
+// coalesceNode is a node in coalesceQueue's intrusive doubly-linked list.
+type coalesceNode[T any] struct {
+	value      T
+	prev, next *coalesceNode[T]
+}
+
+// coalesceQueue is a FIFO queue with O(1) replace-or-append by key, used by
+// CoalescingMailbox to keep at most one pending value per key.
+type coalesceQueue[T any, K comparable] struct {
+	head, tail *coalesceNode[T]
+	index      map[K]*coalesceNode[T]
+	keyFn      func(T) K
+	reducer    func(old, new T) T
+	len        int
+}
+
+func newCoalesceQueue[T any, K comparable](keyFn func(T) K, reducer func(old, new T) T) *coalesceQueue[T, K] {
+	return &coalesceQueue[T, K]{
+		index:   make(map[K]*coalesceNode[T]),
+		keyFn:   keyFn,
+		reducer: reducer,
+	}
+}
+
+func (q *coalesceQueue[T, K]) PushOrReplace(value T) {
+	key := q.keyFn(value)
+
+	if n, ok := q.index[key]; ok {
+		if q.reducer != nil {
+			n.value = q.reducer(n.value, value)
+		} else {
+			n.value = value
+		}
+		return
+	}
+
+	n := &coalesceNode[T]{value: value}
+	if q.tail == nil {
+		q.head, q.tail = n, n
+	} else {
+		n.prev = q.tail
+		q.tail.next = n
+		q.tail = n
+	}
+
+	q.index[key] = n
+	q.len++
+}
+
+func (q *coalesceQueue[T, K]) Front() T {
+	return q.head.value
+}
+
+func (q *coalesceQueue[T, K]) PopFront() {
+	n := q.head
+	q.head = n.next
+	if q.head != nil {
+		q.head.prev = nil
+	} else {
+		q.tail = nil
+	}
+
+	delete(q.index, q.keyFn(n.value))
+	q.len--
+}
+
+func (q *coalesceQueue[T, K]) IsEmpty() bool {
+	return q.len == 0
+}
+
+func (q *coalesceQueue[T, K]) Len() int {
+	return q.len
+}
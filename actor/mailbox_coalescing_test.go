@@ -0,0 +1,85 @@
+package actor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCoalescingMailbox_ReplacesStaleValueForKey(t *testing.T) {
+	type update struct {
+		key   string
+		value int
+	}
+
+	m := NewCoalescingMailbox[update, string](func(u update) string { return u.key })
+	m.Start()
+	defer m.Stop()
+
+	m.SendC() <- update{key: "a", value: 1}
+	m.SendC() <- update{key: "a", value: 2}
+
+	select {
+	case v := <-m.ReceiveC():
+		if v.value != 2 {
+			t.Fatalf("got value %d, want coalesced latest value %d", v.value, 2)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	select {
+	case v := <-m.ReceiveC():
+		t.Fatalf("expected only one coalesced delivery, got extra value %+v", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCoalescingMailbox_PreservesFIFOPositionOfReplacedKey(t *testing.T) {
+	type update struct {
+		key   string
+		value int
+	}
+
+	m := NewCoalescingMailbox[update, string](func(u update) string { return u.key })
+	m.Start()
+	defer m.Stop()
+
+	m.SendC() <- update{key: "a", value: 1}
+	m.SendC() <- update{key: "b", value: 1}
+	m.SendC() <- update{key: "a", value: 2}
+
+	var got []string
+	for i := 0; i < 2; i++ {
+		select {
+		case v := <-m.ReceiveC():
+			got = append(got, v.key)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for delivery")
+		}
+	}
+
+	if want := []string{"a", "b"}; got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got delivery order %v, want %v (key a keeps its original FIFO slot)", got, want)
+	}
+}
+
+func TestCoalescingMailbox_ReducerMergesValues(t *testing.T) {
+	m := NewCoalescingMailbox[int, struct{}](
+		func(int) struct{} { return struct{}{} },
+		OptCoalesceReducer(func(old, new int) int { return old + new }),
+	)
+	m.Start()
+	defer m.Stop()
+
+	m.SendC() <- 1
+	m.SendC() <- 2
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != 3 {
+			t.Fatalf("got %d, want reducer-merged value %d", v, 3)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
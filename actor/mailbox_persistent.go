@@ -0,0 +1,269 @@
+package actor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// MailboxStore is the durability backend for a PersistentMailbox. Append,
+// PeekFront and PopFront operate like the in-memory queue used by other
+// mailbox variants, except every mutation is expected to survive a process
+// restart. Implementations beyond the file-based one shipped here (e.g.
+// BoltDB, Badger) only need to satisfy this interface.
+type MailboxStore[T any] interface {
+	// Append adds v to the back of the store.
+	Append(v T) error
+
+	// PeekFront returns the value at the front of the store without removing
+	// it. ok is false if the store is empty.
+	PeekFront() (v T, ok bool, err error)
+
+	// PopFront removes the value at the front of the store.
+	PopFront() error
+
+	// Len returns the number of values currently in the store.
+	Len() (int, error)
+}
+
+// NewPersistentMailbox returns new Mailbox backed by store, so that messages
+// survive a process crash between being sent and being received. The store
+// has already replayed its on-disk state by the time this returns, so
+// previously queued messages are immediately visible on ReceiveC.
+func NewPersistentMailbox[T any](store MailboxStore[T]) Mailbox[T] {
+	var (
+		sendC    = make(chan T)
+		receiveC = make(chan T)
+		w        = newPersistentMailboxWorker(sendC, receiveC, store)
+	)
+
+	return &mailbox[T]{
+		Actor:    New(w),
+		sendC:    sendC,
+		receiveC: receiveC,
+	}
+}
+
+type persistentMailboxWorker[T any] struct {
+	sendC    chan T
+	receiveC chan T
+	store    MailboxStore[T]
+}
+
+func newPersistentMailboxWorker[T any](sendC, receiveC chan T, store MailboxStore[T]) *persistentMailboxWorker[T] {
+	return &persistentMailboxWorker[T]{
+		sendC:    sendC,
+		receiveC: receiveC,
+		store:    store,
+	}
+}
+
+func (w *persistentMailboxWorker[T]) DoWork(c Context) WorkerStatus {
+	n, err := w.store.Len()
+	if err != nil {
+		return WorkerEnd
+	}
+
+	if n == 0 {
+		select {
+		case value := <-w.sendC:
+			if w.store.Append(value) != nil {
+				return WorkerEnd
+			}
+			return WorkerContinue
+
+		case <-c.Done():
+			return WorkerEnd
+		}
+	}
+
+	front, ok, err := w.store.PeekFront()
+	if err != nil || !ok {
+		return WorkerEnd
+	}
+
+	select {
+	case w.receiveC <- front:
+		if w.store.PopFront() != nil {
+			return WorkerEnd
+		}
+		return WorkerContinue
+
+	case value := <-w.sendC:
+		if w.store.Append(value) != nil {
+			return WorkerEnd
+		}
+		return WorkerContinue
+
+	case <-c.Done():
+		return WorkerEnd
+	}
+}
+
+// OnStop closes the store, if it implements io.Closer, and closes the
+// send/receive channels.
+func (w *persistentMailboxWorker[T]) OnStop() {
+	if closer, ok := w.store.(io.Closer); ok {
+		_ = closer.Close()
+	}
+
+	close(w.sendC)
+	close(w.receiveC)
+}
+
+// FileMailboxStore is a file-based MailboxStore. Records are appended to a
+// single log file as [status byte][4-byte length][payload]; PopFront marks a
+// record consumed in place rather than rewriting the file. On open, the log
+// is scanned once to rebuild the in-memory offsets of not-yet-consumed
+// records.
+type FileMailboxStore[T any] struct {
+	mu      sync.Mutex
+	file    *os.File
+	encode  func(T) ([]byte, error)
+	decode  func([]byte) (T, error)
+	offsets []int64
+}
+
+const (
+	fileMailboxStoreStatusPending  = byte(0)
+	fileMailboxStoreStatusConsumed = byte(1)
+)
+
+// NewFileMailboxStore opens (creating if necessary) the log file at path and
+// replays any not-yet-consumed records into memory. Unlike the Option-based
+// configuration used elsewhere in this package, the codec is supplied
+// directly as encode/decode arguments since MailboxStore has no Option of
+// its own to carry it.
+func NewFileMailboxStore[T any](path string, encode func(T) ([]byte, error), decode func([]byte) (T, error)) (*FileMailboxStore[T], error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("actor: open mailbox store: %w", err)
+	}
+
+	s := &FileMailboxStore[T]{
+		file:   f,
+		encode: encode,
+		decode: decode,
+	}
+
+	if err := s.replay(); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *FileMailboxStore[T]) replay() error {
+	var offset int64
+
+	for {
+		header := make([]byte, 5)
+		if _, err := io.ReadFull(s.file, header); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return fmt.Errorf("actor: replay mailbox store: %w", err)
+		}
+
+		status := header[0]
+		length := binary.BigEndian.Uint32(header[1:])
+
+		if status == fileMailboxStoreStatusPending {
+			s.offsets = append(s.offsets, offset)
+		}
+
+		if _, err := s.file.Seek(int64(length), io.SeekCurrent); err != nil {
+			return fmt.Errorf("actor: replay mailbox store: %w", err)
+		}
+
+		offset += int64(5) + int64(length)
+	}
+
+	return nil
+}
+
+func (s *FileMailboxStore[T]) Append(v T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	payload, err := s.encode(v)
+	if err != nil {
+		return fmt.Errorf("actor: encode mailbox value: %w", err)
+	}
+
+	offset, err := s.file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("actor: append mailbox value: %w", err)
+	}
+
+	header := make([]byte, 5)
+	header[0] = fileMailboxStoreStatusPending
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+
+	if _, err := s.file.Write(append(header, payload...)); err != nil {
+		return fmt.Errorf("actor: append mailbox value: %w", err)
+	}
+
+	s.offsets = append(s.offsets, offset)
+
+	return nil
+}
+
+func (s *FileMailboxStore[T]) PeekFront() (v T, ok bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.offsets) == 0 {
+		return v, false, nil
+	}
+
+	header := make([]byte, 5)
+	if _, err := s.file.ReadAt(header, s.offsets[0]); err != nil {
+		return v, false, fmt.Errorf("actor: peek mailbox value: %w", err)
+	}
+
+	length := binary.BigEndian.Uint32(header[1:])
+	payload := make([]byte, length)
+	if _, err := s.file.ReadAt(payload, s.offsets[0]+5); err != nil {
+		return v, false, fmt.Errorf("actor: peek mailbox value: %w", err)
+	}
+
+	v, err = s.decode(payload)
+	if err != nil {
+		return v, false, fmt.Errorf("actor: decode mailbox value: %w", err)
+	}
+
+	return v, true, nil
+}
+
+func (s *FileMailboxStore[T]) PopFront() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.offsets) == 0 {
+		return fmt.Errorf("actor: pop front of empty mailbox store")
+	}
+
+	if _, err := s.file.WriteAt([]byte{fileMailboxStoreStatusConsumed}, s.offsets[0]); err != nil {
+		return fmt.Errorf("actor: pop mailbox value: %w", err)
+	}
+
+	s.offsets = s.offsets[1:]
+
+	return nil
+}
+
+func (s *FileMailboxStore[T]) Len() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.offsets), nil
+}
+
+// Close closes the underlying log file.
+func (s *FileMailboxStore[T]) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,87 @@
+package actor
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func encodeUint32(v int) ([]byte, error) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(v))
+	return b, nil
+}
+
+func decodeUint32(b []byte) (int, error) {
+	if len(b) != 4 {
+		return 0, errors.New("bad length")
+	}
+	return int(binary.BigEndian.Uint32(b)), nil
+}
+
+func TestPersistentMailbox_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mailbox.log")
+
+	store, err := NewFileMailboxStore[int](path, encodeUint32, decodeUint32)
+	if err != nil {
+		t.Fatalf("NewFileMailboxStore: %v", err)
+	}
+
+	m := NewPersistentMailbox[int](store)
+	m.Start()
+
+	m.SendC() <- 42
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != 42 {
+			t.Fatalf("got %d, want %d", v, 42)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	m.Stop()
+
+	store2, err := NewFileMailboxStore[int](path, encodeUint32, decodeUint32)
+	if err != nil {
+		t.Fatalf("NewFileMailboxStore (reopen): %v", err)
+	}
+
+	if n, err := store2.Len(); err != nil || n != 0 {
+		t.Fatalf("reopened store Len() = %d, %v, want 0, nil (popped value stays popped)", n, err)
+	}
+}
+
+func TestPersistentMailbox_ReplaysUnpoppedValueAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mailbox.log")
+
+	store, err := NewFileMailboxStore[int](path, encodeUint32, decodeUint32)
+	if err != nil {
+		t.Fatalf("NewFileMailboxStore: %v", err)
+	}
+
+	if err := store.Append(7); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	store2, err := NewFileMailboxStore[int](path, encodeUint32, decodeUint32)
+	if err != nil {
+		t.Fatalf("NewFileMailboxStore (reopen): %v", err)
+	}
+
+	m := NewPersistentMailbox[int](store2)
+	m.Start()
+	defer m.Stop()
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != 7 {
+			t.Fatalf("got %d, want replayed value %d", v, 7)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for replayed delivery")
+	}
+}
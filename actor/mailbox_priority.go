@@ -0,0 +1,190 @@
+package actor
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PriorityMailbox is a Mailbox variant with multiple priority lanes. Messages
+// sent via SendCAt(priority) are queued on that lane, and ReceiveC always
+// delivers from the highest non-empty lane first (priority 0 is highest).
+type PriorityMailbox[T any] interface {
+	Mailbox[T]
+
+	// SendCAt returns the channel for sending messages at priority. Lower
+	// values are higher priority; priority must be in [0, levels).
+	SendCAt(priority int) chan<- T
+}
+
+// OptPriorityStarvation sets the number of consecutive times a
+// higher-priority lane is served before the lowest-priority non-empty lane
+// is served instead, guaranteeing it forward progress. The default (0)
+// disables starvation prevention.
+func OptPriorityStarvation(n int) Option {
+	return func(o *options) {
+		o.Mailbox.StarvationInterval = n
+	}
+}
+
+// NewPriorityMailbox returns new PriorityMailbox with the given number of
+// priority lanes. Without OptPriorityStarvation, a constant stream of
+// higher-priority messages can starve lower-priority lanes entirely.
+func NewPriorityMailbox[T any](levels int, opt ...Option) PriorityMailbox[T] {
+	if levels <= 0 {
+		panic("actor: NewPriorityMailbox levels must be > 0")
+	}
+
+	var (
+		opts  = newOptions(opt)
+		mOpts = opts.Mailbox
+
+		sendCs   = make([]chan T, levels)
+		receiveC = make(chan T)
+		queues   = make([]*queue[T], levels)
+	)
+
+	for i := 0; i < levels; i++ {
+		sendCs[i] = make(chan T)
+		queues[i] = newQueue[T](mOpts.Capacity, mOpts.MinCapacity)
+	}
+
+	w := newPriorityMailboxWorker(sendCs, receiveC, queues, mOpts.StarvationInterval)
+
+	return &priorityMailbox[T]{
+		Actor:    New(w),
+		sendCs:   sendCs,
+		receiveC: receiveC,
+	}
+}
+
+type priorityMailbox[T any] struct {
+	Actor
+	sendCs   []chan T
+	receiveC <-chan T
+}
+
+func (m *priorityMailbox[T]) SendC() chan<- T {
+	return m.sendCs[0]
+}
+
+func (m *priorityMailbox[T]) SendCAt(priority int) chan<- T {
+	if priority < 0 || priority >= len(m.sendCs) {
+		panic(fmt.Sprintf("actor: priority %d out of range [0, %d)", priority, len(m.sendCs)))
+	}
+
+	return m.sendCs[priority]
+}
+
+func (m *priorityMailbox[T]) ReceiveC() <-chan T {
+	return m.receiveC
+}
+
+type priorityMailboxWorker[T any] struct {
+	sendCs   []chan T
+	receiveC chan T
+	queues   []*queue[T]
+
+	// starvation is the number of consecutive deliveries from a higher-priority
+	// lane after which the lowest-priority non-empty lane is served instead,
+	// guaranteeing it forward progress. Zero disables starvation prevention.
+	starvation     int
+	sinceLowServed int
+}
+
+func newPriorityMailboxWorker[T any](
+	sendCs []chan T,
+	receiveC chan T,
+	queues []*queue[T],
+	starvation int,
+) *priorityMailboxWorker[T] {
+	return &priorityMailboxWorker[T]{
+		sendCs:     sendCs,
+		receiveC:   receiveC,
+		queues:     queues,
+		starvation: starvation,
+	}
+}
+
+func (w *priorityMailboxWorker[T]) DoWork(c Context) WorkerStatus {
+	lane, overriding := w.nextLane()
+
+	cases := make([]reflect.SelectCase, 0, len(w.sendCs)+2)
+	for _, sendC := range w.sendCs {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(sendC)})
+	}
+	cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.Done())})
+
+	sendCase := -1
+	if lane >= 0 {
+		front := w.queues[lane].Front()
+		sendCase = len(cases)
+		cases = append(cases, reflect.SelectCase{
+			Dir:  reflect.SelectSend,
+			Chan: reflect.ValueOf(w.receiveC),
+			// front is taken by address so a nil interface value of T (e.g.
+			// PriorityMailbox[error]) still yields a valid, typed
+			// reflect.Value; reflect.ValueOf(front) directly would be
+			// invalid for a nil interface and panic in reflect.Select.
+			Send: reflect.ValueOf(&front).Elem(),
+		})
+	}
+
+	chosen, recv, _ := reflect.Select(cases)
+
+	switch {
+	case chosen < len(w.sendCs):
+		w.queues[chosen].PushBack(recv.Interface().(T))
+		return WorkerContinue
+
+	case chosen == len(w.sendCs):
+		return WorkerEnd
+
+	case chosen == sendCase:
+		w.queues[lane].PopFront()
+		if overriding {
+			w.sinceLowServed = 0
+		} else {
+			w.sinceLowServed++
+		}
+		return WorkerContinue
+
+	default:
+		return WorkerContinue
+	}
+}
+
+// nextLane returns the index of the lane to serve next (-1 if every lane is
+// empty) and whether that lane was chosen as a starvation override of the
+// otherwise-highest-priority non-empty lane. It only selects a candidate;
+// sinceLowServed is mutated by the caller once a delivery from lane actually
+// happens, since a tick can also be consumed by an unrelated incoming send.
+func (w *priorityMailboxWorker[T]) nextLane() (lane int, overriding bool) {
+	highest := -1
+	for i, q := range w.queues {
+		if !q.IsEmpty() {
+			highest = i
+			break
+		}
+	}
+
+	if highest < 0 {
+		return -1, false
+	}
+
+	if w.starvation > 0 && w.sinceLowServed >= w.starvation {
+		for i := len(w.queues) - 1; i > highest; i-- {
+			if !w.queues[i].IsEmpty() {
+				return i, true
+			}
+		}
+	}
+
+	return highest, false
+}
+
+func (w *priorityMailboxWorker[T]) OnStop() {
+	for _, sendC := range w.sendCs {
+		close(sendC)
+	}
+	close(w.receiveC)
+}
@@ -0,0 +1,89 @@
+package actor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPriorityMailbox_HighestLaneServedFirst(t *testing.T) {
+	m := NewPriorityMailbox[string](2)
+	m.Start()
+	defer m.Stop()
+
+	m.SendCAt(1) <- "low"
+	m.SendCAt(0) <- "high"
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != "high" {
+			t.Fatalf("got %q, want %q to be served first", v, "high")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != "low" {
+			t.Fatalf("got %q, want %q", v, "low")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+}
+
+func TestPriorityMailbox_StarvationPreventionServesLowerLane(t *testing.T) {
+	const starvation = 3
+
+	m := NewPriorityMailbox[int](2, OptPriorityStarvation(starvation))
+	m.Start()
+	defer m.Stop()
+
+	// Queue everything before receiving, so the low-priority message waits
+	// behind starvation+2 high-priority ones.
+	m.SendCAt(1) <- -1
+	for i := 0; i < starvation+2; i++ {
+		m.SendCAt(0) <- i
+	}
+
+	served := make([]int, 0, starvation+3)
+	for len(served) < starvation+3 {
+		select {
+		case v := <-m.ReceiveC():
+			served = append(served, v)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for delivery, got %v so far", served)
+		}
+	}
+
+	// Without starvation prevention -1 would come last; with it, it must be
+	// served right after starvation high-priority deliveries.
+	found := false
+	for _, v := range served[:starvation+1] {
+		if v == -1 {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		t.Fatalf("low-priority message never served despite starvation prevention, got %v", served)
+	}
+}
+
+func TestPriorityMailbox_DeliversNilInterfaceValue(t *testing.T) {
+	m := NewPriorityMailbox[error](1)
+	m.Start()
+	defer m.Stop()
+
+	m.SendC() <- nil
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != nil {
+			t.Fatalf("got %v, want nil", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery of a nil interface value")
+	}
+}
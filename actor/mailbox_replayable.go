@@ -0,0 +1,285 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMailboxStopped is returned by ReplayableMailbox.Ack, and reported via a
+// false Has, when called after the mailbox has been Stopped.
+var ErrMailboxStopped = errors.New("actor: mailbox is stopped")
+
+// ReplayableMailbox is a Mailbox variant for at-least-once delivery. Every
+// message delivered via ReceiveC is kept in an in-memory replay buffer, keyed
+// by a caller-supplied key function, until it is explicitly Ack-ed. Reset
+// re-queues everything still in the replay buffer at the head of the queue,
+// in original order, so a downstream Actor that was restarted (e.g. behind a
+// Combine) sees the same messages again.
+type ReplayableMailbox[T any, K comparable] interface {
+	Mailbox[T]
+
+	// Ack marks the message identified by key as processed, removing it from
+	// the replay buffer. It returns an error if key is not currently in-flight.
+	Ack(key K) error
+
+	// Reset re-queues all in-flight (unacked) messages at the head of the
+	// queue, in the order they were originally sent.
+	Reset()
+
+	// Has reports whether a message with key is currently queued or
+	// in-flight, for dedup on the send path.
+	Has(key K) bool
+}
+
+// NewReplayableMailbox returns new ReplayableMailbox. keyFn extracts the
+// dedup/ack key from a message; messages whose key is already queued or
+// in-flight are silently dropped on send.
+func NewReplayableMailbox[T any, K comparable](keyFn func(T) K, opt ...Option) ReplayableMailbox[T, K] {
+	var (
+		opts     = newOptions(opt)
+		mOpts    = opts.Mailbox
+		sendC    = make(chan T)
+		receiveC = make(chan T)
+		ackC     = make(chan replayAckRequest[K])
+		resetC   = make(chan struct{})
+		hasC     = make(chan replayHasRequest[K])
+		queue    = newQueue[T](mOpts.Capacity, mOpts.MinCapacity)
+		w        = newReplayableMailboxWorker(sendC, receiveC, ackC, resetC, hasC, queue, keyFn)
+	)
+
+	return &replayableMailbox[T, K]{
+		Actor:    New(w),
+		sendC:    sendC,
+		receiveC: receiveC,
+		ackC:     ackC,
+		resetC:   resetC,
+		hasC:     hasC,
+	}
+}
+
+type replayableMailbox[T any, K comparable] struct {
+	Actor
+	sendC    chan<- T
+	receiveC <-chan T
+	ackC     chan<- replayAckRequest[K]
+	resetC   chan<- struct{}
+	hasC     chan<- replayHasRequest[K]
+}
+
+func (m *replayableMailbox[T, K]) SendC() chan<- T {
+	return m.sendC
+}
+
+func (m *replayableMailbox[T, K]) ReceiveC() <-chan T {
+	return m.receiveC
+}
+
+// Ack sends key on ackC, where it is received by the worker. It returns
+// ErrMailboxStopped instead of blocking forever if the mailbox has already
+// been Stopped.
+func (m *replayableMailbox[T, K]) Ack(key K) (err error) {
+	defer func() {
+		if recover() != nil {
+			err = ErrMailboxStopped
+		}
+	}()
+
+	reply := make(chan error, 1)
+	m.ackC <- replayAckRequest[K]{key: key, reply: reply}
+	return <-reply
+}
+
+// Reset is a no-op instead of blocking forever if the mailbox has already
+// been Stopped.
+func (m *replayableMailbox[T, K]) Reset() {
+	defer func() { recover() }()
+	m.resetC <- struct{}{}
+}
+
+// Has returns false instead of blocking forever if the mailbox has already
+// been Stopped.
+func (m *replayableMailbox[T, K]) Has(key K) (has bool) {
+	defer func() {
+		if recover() != nil {
+			has = false
+		}
+	}()
+
+	reply := make(chan bool, 1)
+	m.hasC <- replayHasRequest[K]{key: key, reply: reply}
+	return <-reply
+}
+
+type replayAckRequest[K comparable] struct {
+	key   K
+	reply chan error
+}
+
+type replayHasRequest[K comparable] struct {
+	key   K
+	reply chan bool
+}
+
+type replayableMailboxWorker[T any, K comparable] struct {
+	sendC    chan T
+	receiveC chan T
+	ackC     chan replayAckRequest[K]
+	resetC   chan struct{}
+	hasC     chan replayHasRequest[K]
+	queue    *queue[T]
+	keyFn    func(T) K
+
+	pending       map[K]struct{}
+	inFlight      map[K]T
+	inFlightOrder []K
+}
+
+func newReplayableMailboxWorker[T any, K comparable](
+	sendC,
+	receiveC chan T,
+	ackC chan replayAckRequest[K],
+	resetC chan struct{},
+	hasC chan replayHasRequest[K],
+	queue *queue[T],
+	keyFn func(T) K,
+) *replayableMailboxWorker[T, K] {
+	return &replayableMailboxWorker[T, K]{
+		sendC:    sendC,
+		receiveC: receiveC,
+		ackC:     ackC,
+		resetC:   resetC,
+		hasC:     hasC,
+		queue:    queue,
+		keyFn:    keyFn,
+		pending:  make(map[K]struct{}),
+		inFlight: make(map[K]T),
+	}
+}
+
+func (w *replayableMailboxWorker[T, K]) DoWork(c Context) WorkerStatus {
+	if w.queue.IsEmpty() {
+		select {
+		case value := <-w.sendC:
+			w.enqueue(value)
+			return WorkerContinue
+
+		case req := <-w.ackC:
+			w.ack(req)
+			return WorkerContinue
+
+		case <-w.resetC:
+			w.reset()
+			return WorkerContinue
+
+		case req := <-w.hasC:
+			req.reply <- w.has(req.key)
+			return WorkerContinue
+
+		case <-c.Done():
+			return WorkerEnd
+		}
+	}
+
+	select {
+	case w.receiveC <- w.queue.Front():
+		w.deliverFront()
+		return WorkerContinue
+
+	case value := <-w.sendC:
+		w.enqueue(value)
+		return WorkerContinue
+
+	case req := <-w.ackC:
+		w.ack(req)
+		return WorkerContinue
+
+	case <-w.resetC:
+		w.reset()
+		return WorkerContinue
+
+	case req := <-w.hasC:
+		req.reply <- w.has(req.key)
+		return WorkerContinue
+
+	case <-c.Done():
+		return WorkerEnd
+	}
+}
+
+func (w *replayableMailboxWorker[T, K]) enqueue(value T) {
+	key := w.keyFn(value)
+	if w.has(key) {
+		return
+	}
+
+	w.pending[key] = struct{}{}
+	w.queue.PushBack(value)
+}
+
+func (w *replayableMailboxWorker[T, K]) deliverFront() {
+	value := w.queue.Front()
+	w.queue.PopFront()
+
+	key := w.keyFn(value)
+	delete(w.pending, key)
+	w.inFlight[key] = value
+	w.inFlightOrder = append(w.inFlightOrder, key)
+}
+
+func (w *replayableMailboxWorker[T, K]) ack(req replayAckRequest[K]) {
+	if _, ok := w.inFlight[req.key]; !ok {
+		req.reply <- fmt.Errorf("actor: key %v is not in-flight", req.key)
+		return
+	}
+
+	delete(w.inFlight, req.key)
+	for i, key := range w.inFlightOrder {
+		if key == req.key {
+			w.inFlightOrder = append(w.inFlightOrder[:i], w.inFlightOrder[i+1:]...)
+			break
+		}
+	}
+
+	req.reply <- nil
+}
+
+func (w *replayableMailboxWorker[T, K]) reset() {
+	if len(w.inFlightOrder) == 0 {
+		return
+	}
+
+	rest := make([]T, 0, w.queue.Len())
+	for !w.queue.IsEmpty() {
+		rest = append(rest, w.queue.Front())
+		w.queue.PopFront()
+	}
+
+	for _, key := range w.inFlightOrder {
+		w.queue.PushBack(w.inFlight[key])
+		w.pending[key] = struct{}{}
+	}
+
+	for _, value := range rest {
+		w.queue.PushBack(value)
+	}
+
+	w.inFlight = make(map[K]T)
+	w.inFlightOrder = nil
+}
+
+func (w *replayableMailboxWorker[T, K]) has(key K) bool {
+	if _, ok := w.pending[key]; ok {
+		return true
+	}
+
+	_, ok := w.inFlight[key]
+	return ok
+}
+
+func (w *replayableMailboxWorker[T, K]) OnStop() {
+	close(w.sendC)
+	close(w.receiveC)
+	close(w.ackC)
+	close(w.resetC)
+	close(w.hasC)
+}
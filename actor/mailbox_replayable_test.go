@@ -0,0 +1,113 @@
+package actor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReplayableMailbox_AckRemovesFromInFlight(t *testing.T) {
+	m := NewReplayableMailbox[string, string](func(v string) string { return v })
+	m.Start()
+	defer m.Stop()
+
+	m.SendC() <- "a"
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != "a" {
+			t.Fatalf("got %q, want %q", v, "a")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	if err := m.Ack("a"); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	if err := m.Ack("a"); err == nil {
+		t.Fatal("expected error acking a key that is no longer in-flight")
+	}
+}
+
+func TestReplayableMailbox_ResetRedeliversUnacked(t *testing.T) {
+	m := NewReplayableMailbox[string, string](func(v string) string { return v })
+	m.Start()
+	defer m.Stop()
+
+	m.SendC() <- "a"
+
+	select {
+	case <-m.ReceiveC():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for first delivery")
+	}
+
+	m.Reset()
+
+	select {
+	case v := <-m.ReceiveC():
+		if v != "a" {
+			t.Fatalf("got %q after Reset, want %q", v, "a")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for redelivery after Reset")
+	}
+}
+
+func TestReplayableMailbox_HasDedupsQueuedAndInFlight(t *testing.T) {
+	m := NewReplayableMailbox[string, string](func(v string) string { return v })
+	m.Start()
+	defer m.Stop()
+
+	m.SendC() <- "a"
+
+	if !m.Has("a") {
+		t.Fatal("expected Has(\"a\") to be true once queued")
+	}
+
+	m.SendC() <- "a"
+
+	select {
+	case <-m.ReceiveC():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	if !m.Has("a") {
+		t.Fatal("expected Has(\"a\") to be true while in-flight")
+	}
+
+	select {
+	case <-m.ReceiveC():
+		t.Fatal("duplicate send with same key should have been dropped")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestReplayableMailbox_AckResetHasAfterStopReturnPromptly(t *testing.T) {
+	m := NewReplayableMailbox[string, string](func(v string) string { return v })
+	m.Start()
+	m.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		if err := m.Ack("a"); err != ErrMailboxStopped {
+			t.Errorf("Ack after Stop = %v, want %v", err, ErrMailboxStopped)
+		}
+
+		m.Reset()
+
+		if m.Has("a") {
+			t.Error("Has after Stop = true, want false")
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Ack/Reset/Has hung instead of returning after Stop")
+	}
+}